@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fox-one/mixin-sdk-go/v2"
+	"github.com/gofrs/uuid"
+	"github.com/zeebo/blake3"
+)
+
+// GroupPhase tracks how far a UTXO group has progressed through the
+// build/submit/confirm pipeline, so a resumed run knows what is safe to skip.
+type GroupPhase string
+
+const (
+	GroupPhaseBuilt     GroupPhase = "built"
+	GroupPhaseSubmitted GroupPhase = "submitted"
+	GroupPhaseConfirmed GroupPhase = "confirmed"
+)
+
+// GroupState is the checkpoint record for one batch of UTXOs bundled into a
+// single safe transaction request.
+type GroupState struct {
+	AssetID   string     `json:"asset_id"`
+	RequestID string     `json:"request_id"`
+	Utxos     []string   `json:"utxos"`
+	Phase     GroupPhase `json:"phase"`
+}
+
+// CheckpointState is the sidecar file written next to the keystore that
+// records migration progress so a killed process can resume without
+// re-submitting already-built transaction requests.
+type CheckpointState struct {
+	path string
+	mu   sync.Mutex
+
+	Salt   string                 `json:"salt"`
+	Groups map[string]*GroupState `json:"groups"`
+}
+
+func loadCheckpointState(path string) (*CheckpointState, error) {
+	state := &CheckpointState{path: path, Groups: map[string]*GroupState{}}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		state.Salt = uuid.Must(uuid.NewV4()).String()
+		return state, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("open checkpoint state failed: %w", err)
+	}
+
+	defer f.Close()
+
+	if err := json.NewDecoder(f).Decode(state); err != nil {
+		return nil, fmt.Errorf("decode checkpoint state failed: %w", err)
+	}
+
+	state.path = path
+	if state.Groups == nil {
+		state.Groups = map[string]*GroupState{}
+	}
+
+	return state, nil
+}
+
+// save is safe to call from concurrent workers: each call takes an
+// exclusive lock on the state for the duration of the write so parallel
+// group submissions never interleave their writes to the sidecar file.
+func (s *CheckpointState) save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("open checkpoint state failed: %w", err)
+	}
+
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("save checkpoint state failed: %w", err)
+	}
+
+	return nil
+}
+
+// group returns a copy of the record for key, creating an empty one on
+// first use. It is a snapshot: mutating the returned value does not affect
+// the checkpoint, since save() encodes the map under the same lock and a
+// worker writing straight into a shared *GroupState would race that
+// encode. Use ensureRequestID/markBuilt/markConfirmed to mutate.
+func (s *CheckpointState) group(key string) GroupState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	gs, ok := s.Groups[key]
+	if !ok {
+		gs = &GroupState{}
+		s.Groups[key] = gs
+	}
+
+	return *gs
+}
+
+// ensureRequestID returns the current phase and RequestID for key,
+// deriving and persisting a RequestID on first use. It is safe to call
+// from concurrent workers.
+func (s *CheckpointState) ensureRequestID(key, receiver string) (GroupPhase, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	gs, ok := s.Groups[key]
+	if !ok {
+		gs = &GroupState{}
+		s.Groups[key] = gs
+	}
+
+	if gs.RequestID == "" {
+		gs.RequestID = s.requestIDLocked(key, receiver)
+	}
+
+	return gs.Phase, gs.RequestID
+}
+
+// markBuilt records that the group at key was built into a transaction
+// request spending utxos. Safe to call from concurrent workers.
+func (s *CheckpointState) markBuilt(key, assetID string, utxos []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	gs := s.Groups[key]
+	gs.AssetID = assetID
+	gs.Utxos = utxos
+	gs.Phase = GroupPhaseBuilt
+}
+
+// markConfirmed records that the group at key was submitted and confirmed.
+// Safe to call from concurrent workers.
+func (s *CheckpointState) markConfirmed(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if gs, ok := s.Groups[key]; ok {
+		gs.Phase = GroupPhaseConfirmed
+	}
+}
+
+// requestIDLocked deterministically derives the RequestID for a group of
+// UTXOs so that rebuilding the same group after a restart produces the
+// exact same request instead of a duplicate spend. Callers must hold s.mu.
+func (s *CheckpointState) requestIDLocked(key, receiver string) string {
+	sum := blake3.Sum256([]byte(key + ":" + receiver + ":" + s.Salt))
+	sum[6] = (sum[6] & 0x0f) | 0x30
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return uuid.FromBytesOrNil(sum[:16]).String()
+}
+
+// requestID is the read-only counterpart to ensureRequestID: it derives
+// what the RequestID for key would be without persisting it, for callers
+// like buildMigrationPlan that must never write to the checkpoint state.
+func (s *CheckpointState) requestID(key, receiver string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requestIDLocked(key, receiver)
+}
+
+// groupKey content-addresses a batch of UTXOs by their output ids, so the
+// same set of UTXOs always maps to the same checkpoint record regardless of
+// where it lands in a re-paged listing.
+func groupKey(utxos []*mixin.SafeUtxo) string {
+	return groupKeyFromIDs(utxoOutputIDs(utxos))
+}
+
+// groupKeyFromIDs re-derives a group key from the output ids recorded in a
+// checkpoint or bundle group, so a resumed run or a submitted bundle maps
+// back onto the same checkpoint record.
+func groupKeyFromIDs(ids []string) string {
+	sorted := append([]string(nil), ids...)
+	sort.Strings(sorted)
+	sum := blake3.Sum256([]byte(strings.Join(sorted, ",")))
+	return fmt.Sprintf("%x", sum)
+}
+
+func utxoOutputIDs(utxos []*mixin.SafeUtxo) []string {
+	ids := make([]string, len(utxos))
+	for i, utxo := range utxos {
+		ids[i] = utxo.OutputID
+	}
+	return ids
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/fox-one/mixin-sdk-go/v2"
+)
+
+func TestGroupKeyIsOrderIndependent(t *testing.T) {
+	a := []*mixin.SafeUtxo{{OutputID: "a"}, {OutputID: "b"}, {OutputID: "c"}}
+	b := []*mixin.SafeUtxo{{OutputID: "c"}, {OutputID: "a"}, {OutputID: "b"}}
+
+	if groupKey(a) != groupKey(b) {
+		t.Fatalf("groupKey should not depend on utxo order: %s != %s", groupKey(a), groupKey(b))
+	}
+
+	c := []*mixin.SafeUtxo{{OutputID: "a"}, {OutputID: "b"}}
+	if groupKey(a) == groupKey(c) {
+		t.Fatalf("groupKey should differ for a different set of utxos")
+	}
+}
+
+func TestRequestIDIsDeterministicPerSalt(t *testing.T) {
+	state := &CheckpointState{Salt: "fixed-salt", Groups: map[string]*GroupState{}}
+
+	first := state.requestID("group-key", "receiver-a")
+	second := state.requestID("group-key", "receiver-a")
+	if first != second {
+		t.Fatalf("requestID should be deterministic for the same key/receiver/salt: %s != %s", first, second)
+	}
+
+	if other := state.requestID("group-key", "receiver-b"); other == first {
+		t.Fatalf("requestID should differ for a different receiver")
+	}
+
+	otherSalt := &CheckpointState{Salt: "different-salt", Groups: map[string]*GroupState{}}
+	if other := otherSalt.requestID("group-key", "receiver-a"); other == first {
+		t.Fatalf("requestID should differ for a different salt, since the salt is what makes it unguessable across state files")
+	}
+}
+
+func TestEnsureRequestIDPersistsAcrossCalls(t *testing.T) {
+	dir := t.TempDir()
+	state, err := loadCheckpointState(dir + "/state.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, first := state.ensureRequestID("group-key", "receiver-a")
+	_, second := state.ensureRequestID("group-key", "receiver-a")
+	if first != second {
+		t.Fatalf("ensureRequestID should return the same RequestID once persisted: %s != %s", first, second)
+	}
+}
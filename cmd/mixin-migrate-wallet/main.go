@@ -0,0 +1,87 @@
+// Command mixin-migrate-wallet is the offline counterpart to mixin-migrate:
+// it holds only a spend key, never a Mixin API keystore, and its only job is
+// to sign the unsigned bundles produced by `mixin-migrate plan` so the
+// spend key never has to live on a host that talks to the Mixin API.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/fox-one/mixin-migrate/internal/bundle"
+	"github.com/fox-one/mixin-migrate/internal/cli"
+	"github.com/fox-one/mixin-sdk-go/v2/mixinnet"
+)
+
+var (
+	keystorePath = flag.String("key", "", "wallet keystore path (must contain only spend_key)")
+	inPath       = flag.String("bundle", "", "unsigned bundle path")
+	outPath      = flag.String("out", "", "signed bundle output path (default: overwrite -bundle)")
+)
+
+// walletKeystore deliberately carries nothing but the spend key, so this
+// binary never needs to see the Mixin API keystore.
+type walletKeystore struct {
+	SpendKey string `json:"spend_key"`
+}
+
+func main() {
+	flag.Parse()
+
+	if *keystorePath == "" || *inPath == "" {
+		log.Fatalln("-key and -bundle are required")
+	}
+
+	key, err := loadWalletKeystore(*keystorePath)
+	if err != nil {
+		log.Fatalln("load wallet keystore failed:", err)
+	}
+
+	spendKey, err := mixinnet.KeyFromString(key.SpendKey)
+	if err != nil {
+		log.Fatalln("invalid spend key:", err)
+	}
+
+	b, err := bundle.Load(*inPath)
+	if err != nil {
+		log.Fatalln("load bundle failed:", err)
+	}
+
+	log.Printf("about to sign %d groups totaling %s to %v (threshold %d)\n", len(b.Groups), b.TotalAmount(), b.Receivers, b.Threshold)
+	if !cli.Confirm("Sign") {
+		return
+	}
+
+	if err := b.Sign(spendKey); err != nil {
+		log.Fatalln("sign bundle failed:", err)
+	}
+
+	out := *outPath
+	if out == "" {
+		out = *inPath
+	}
+
+	if err := bundle.Save(out, b); err != nil {
+		log.Fatalln("save signed bundle failed:", err)
+	}
+
+	log.Println("wrote signed bundle to", out)
+}
+
+func loadWalletKeystore(path string) (*walletKeystore, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	var key walletKeystore
+	if err := json.NewDecoder(f).Decode(&key); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
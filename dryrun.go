@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/fox-one/mixin-sdk-go/v2"
+	"github.com/shopspring/decimal"
+)
+
+// AssetPlan is the planned migration work for one asset: how many outgoing
+// transactions it will take, the total amount moved, and (for safe assets)
+// the deterministic RequestID each transaction will use.
+type AssetPlan struct {
+	AssetID    string          `json:"asset_id"`
+	Symbol     string          `json:"symbol"`
+	Legacy     bool            `json:"legacy"`
+	UtxoCount  int             `json:"utxo_count,omitempty"`
+	Outgoing   int             `json:"outgoing_transactions"`
+	Amount     decimal.Decimal `json:"amount"`
+	RequestIDs []string        `json:"request_ids,omitempty"`
+}
+
+// MigrationPlan is the structured, human-readable summary of everything a
+// migration run would do, computed entirely from read-only API calls.
+type MigrationPlan struct {
+	Receivers  []string     `json:"receivers"`
+	Threshold  uint8        `json:"threshold"`
+	MixAddress string       `json:"mix_address"`
+	Assets     []*AssetPlan `json:"assets"`
+}
+
+// buildMigrationPlan mirrors the read-side steps of migrateLegacyAssets and
+// planSafeAssets (grouping UTXOs, deriving RequestIDs) without ever calling
+// Transfer, ModifyPin, SafeMigrate, or SafeCreateTransactionRequest, and
+// without writing to the checkpoint state, so it is always safe to run
+// against a real keystore. state and safeAssets are the ones the caller
+// already loaded/fetched, so printing the plan does not page the safe
+// UTXOs or resolve asset symbols a second time before planSafeAssets does
+// the real work.
+func (r *runner) buildMigrationPlan(ctx context.Context, state *CheckpointState, safeAssets []*safeAssetGroup) (*MigrationPlan, error) {
+	plan := &MigrationPlan{
+		Receivers:  r.receivers,
+		Threshold:  r.threshold,
+		MixAddress: mixin.RequireNewMixAddress(r.receivers, r.threshold).String(),
+	}
+
+	legacyAssets, err := r.client.ReadAssets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list legacy assets failed: %w", err)
+	}
+
+	for _, asset := range legacyAssets {
+		if asset.Balance.IsZero() || !r.assetAllowed(asset.AssetID) {
+			continue
+		}
+
+		plan.Assets = append(plan.Assets, &AssetPlan{
+			AssetID:  asset.AssetID,
+			Symbol:   asset.Symbol,
+			Legacy:   true,
+			Outgoing: len(r.receivers),
+			Amount:   asset.Balance,
+		})
+	}
+
+	for _, sa := range safeAssets {
+		ap := &AssetPlan{AssetID: sa.assetID, Symbol: sa.symbol, UtxoCount: len(sa.utxos)}
+
+		for idx := 0; idx < len(sa.utxos); idx += *spendGroupCount {
+			spends := sa.utxos[idx:min(len(sa.utxos), idx+*spendGroupCount)]
+			key := groupKey(spends)
+
+			requestID := state.group(key).RequestID
+			if requestID == "" {
+				requestID = state.requestID(key, r.receiverKey())
+			}
+
+			ap.Outgoing++
+			ap.Amount = ap.Amount.Add(sumUtxos(spends))
+			ap.RequestIDs = append(ap.RequestIDs, requestID)
+		}
+
+		plan.Assets = append(plan.Assets, ap)
+	}
+
+	return plan, nil
+}
+
+// printMigrationPlan renders a plan as JSON followed by a pretty table, so
+// an operator can both diff it programmatically and eyeball it before
+// confirming.
+func printMigrationPlan(plan *MigrationPlan) {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err == nil {
+		fmt.Println(string(data))
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "ASSET\tUTXOS\tTXNS\tAMOUNT")
+	for _, ap := range plan.Assets {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", ap.Symbol, ap.UtxoCount, ap.Outgoing, ap.Amount)
+	}
+	if err := w.Flush(); err != nil {
+		log.Println("print migration plan failed:", err)
+	}
+}
@@ -0,0 +1,145 @@
+// Package bundle defines the JSON envelope that carries an unsigned or
+// signed safe transaction between the online mixin-migrate planner/submitter
+// and the offline mixin-migrate-wallet signer, so the spend key never has to
+// live on the same host as the Mixin API credentials.
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/fox-one/mixin-sdk-go/v2"
+	"github.com/fox-one/mixin-sdk-go/v2/mixinnet"
+	"github.com/shopspring/decimal"
+)
+
+// Version is bumped whenever the envelope shape changes in an
+// incompatible way.
+const Version = 1
+
+// Group is one safe transaction request: the UTXOs it spends, the raw
+// transaction (unsigned until the wallet signs it), and whatever the wallet
+// needs to sign it.
+type Group struct {
+	AssetID        string          `json:"asset_id"`
+	Symbol         string          `json:"symbol"`
+	RequestID      string          `json:"request_id"`
+	Utxos          []string        `json:"utxos"`
+	Amount         decimal.Decimal `json:"amount"`
+	RawTransaction string          `json:"raw_transaction"`
+	Views          []mixinnet.Key  `json:"views,omitempty"`
+	Signed         bool            `json:"signed"`
+}
+
+// Manifest summarizes one asset's worth of groups so a human can sanity
+// check a bundle before signing or submitting it.
+type Manifest struct {
+	AssetID string          `json:"asset_id"`
+	Symbol  string          `json:"symbol"`
+	Groups  int             `json:"groups"`
+	Utxos   int             `json:"utxos"`
+	Amount  decimal.Decimal `json:"amount"`
+}
+
+// Bundle is the envelope written to disk by `mixin-migrate plan`, handed to
+// `mixin-migrate-wallet sign`, and handed back to `mixin-migrate submit`.
+type Bundle struct {
+	Version   int         `json:"version"`
+	Receivers []string    `json:"receivers"`
+	Threshold uint8       `json:"threshold"`
+	Manifests []*Manifest `json:"manifests"`
+	Groups    []*Group    `json:"groups"`
+}
+
+// Load reads a bundle from path and rejects envelopes from an incompatible
+// future version.
+func Load(path string) (*Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open bundle failed: %w", err)
+	}
+
+	defer f.Close()
+
+	var b Bundle
+	if err := json.NewDecoder(f).Decode(&b); err != nil {
+		return nil, fmt.Errorf("decode bundle failed: %w", err)
+	}
+
+	if b.Version > Version {
+		return nil, fmt.Errorf("unsupported bundle version %d, expected <= %d", b.Version, Version)
+	}
+
+	return &b, nil
+}
+
+// Save writes a bundle to path, creating or truncating it.
+func Save(path string, b *Bundle) error {
+	b.Version = Version
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("open bundle failed: %w", err)
+	}
+
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(b); err != nil {
+		return fmt.Errorf("save bundle failed: %w", err)
+	}
+
+	return nil
+}
+
+// TotalAmount sums the manifest amounts, e.g. for a one-line "you are about
+// to move X" confirmation.
+func (b *Bundle) TotalAmount() decimal.Decimal {
+	var total decimal.Decimal
+	for _, m := range b.Manifests {
+		total = total.Add(m.Amount)
+	}
+	return total
+}
+
+// Sign signs every unsigned group in the bundle in place with the given
+// spend key. It is the one signing path shared by the offline
+// mixin-migrate-wallet binary and the online all-in-one `run` command.
+func (b *Bundle) Sign(spendKey mixinnet.Key) error {
+	for _, g := range b.Groups {
+		if err := g.Sign(spendKey); err != nil {
+			return fmt.Errorf("sign group %s failed: %w", g.RequestID, err)
+		}
+	}
+
+	return nil
+}
+
+// Sign turns an unsigned group's raw transaction into a signed one. It is a
+// no-op if the group is already signed, so re-running it is safe.
+func (g *Group) Sign(spendKey mixinnet.Key) error {
+	if g.Signed {
+		return nil
+	}
+
+	tx, err := mixinnet.TransactionFromRaw(g.RawTransaction)
+	if err != nil {
+		return fmt.Errorf("parse raw transaction failed: %w", err)
+	}
+
+	if err := mixin.SafeSignTransaction(tx, spendKey, g.Views, 0); err != nil {
+		return fmt.Errorf("sign transaction failed: %w", err)
+	}
+
+	raw, err := tx.Dump()
+	if err != nil {
+		return fmt.Errorf("dump transaction failed: %w", err)
+	}
+
+	g.RawTransaction = raw
+	g.Views = nil
+	g.Signed = true
+	return nil
+}
@@ -0,0 +1,25 @@
+// Package cli holds small helpers shared by the mixin-migrate and
+// mixin-migrate-wallet binaries.
+package cli
+
+import (
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+// Confirm prompts the user with a y/n question and reports whether they
+// answered yes.
+func Confirm(label string) bool {
+	prompt := promptui.Prompt{
+		Label:     label,
+		IsConfirm: true,
+	}
+
+	result, err := prompt.Run()
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(result, "y")
+}
@@ -9,11 +9,13 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/fox-one/mixin-migrate/internal/bundle"
+	"github.com/fox-one/mixin-migrate/internal/cli"
 	"github.com/fox-one/mixin-sdk-go/v2"
 	"github.com/fox-one/mixin-sdk-go/v2/mixinnet"
-	"github.com/manifoldco/promptui"
 	"github.com/shopspring/decimal"
 	"github.com/spf13/cast"
 )
@@ -21,6 +23,14 @@ import (
 var (
 	keystorePath    = flag.String("key", "", "keystore path")
 	spendGroupCount = flag.Int("group", 256, "spend group count")
+	statePath       = flag.String("state", "", "checkpoint state path (default: <key>.state.json)")
+	bundlePath      = flag.String("bundle", "", "bundle file path, for the plan/submit commands (default: <key>.bundle.json)")
+	dryRun          = flag.Bool("dry-run", false, "print the migration plan and exit without making any changes")
+	threshold       = flag.Int("threshold", 1, "receiver threshold, for an M-of-N mix address when more than one receiver is given")
+	includeAssets   = flag.String("assets", "", "comma-separated asset ids to migrate (default: all)")
+	excludeAssets   = flag.String("skip-assets", "", "comma-separated asset ids to leave untouched")
+	concurrency     = flag.Int("concurrency", 4, "number of UTXO groups to plan/submit concurrently")
+	rps             = flag.Float64("rps", 5, "max Mixin API requests per second, shared across all workers")
 )
 
 func main() {
@@ -32,6 +42,13 @@ func main() {
 		log.Fatalln("receiver id is required")
 	}
 
+	mode := "run"
+	switch args[0] {
+	case "resume", "status", "plan", "submit":
+		mode = args[0]
+		args = args[1:]
+	}
+
 	if *spendGroupCount <= 0 || *spendGroupCount > 256 {
 		log.Fatalln("invalid spend group count")
 	}
@@ -46,29 +63,122 @@ func main() {
 		log.Fatalln("new client failed:", err)
 	}
 
-	receiver, err := fetchUserInfo(ctx, args[0])
+	r := &runner{
+		client:        client,
+		key:           key,
+		output:        *keystorePath,
+		includeAssets: parseAssetFilter(*includeAssets),
+		excludeAssets: parseAssetFilter(*excludeAssets),
+		limiter:       newRateLimiter(*rps),
+	}
+
+	// status and submit act on state already recorded in the checkpoint
+	// and bundle, neither of which reads a receiver back off the command
+	// line: the receivers were fixed when the bundle was planned. Taking
+	// (and validating) receiver arguments here anyway would look like it
+	// selects the destination, when it would actually be silently
+	// ignored in favor of whatever the bundle already encodes.
+	if mode == "status" || mode == "submit" {
+		if len(args) != 0 {
+			log.Fatalln("status/submit do not take receiver arguments; the receivers were fixed when the bundle was planned")
+		}
+
+		if mode == "status" {
+			if err := r.printSafeAssetsStatus(ctx); err != nil {
+				log.Fatalln("print status failed:", err)
+			}
+			return
+		}
+
+		b, err := bundle.Load(r.bundlePath())
+		if err != nil {
+			log.Fatalln("load bundle failed:", err)
+		}
+
+		if err := r.submitBundle(ctx, b); err != nil {
+			log.Fatalln("submit bundle failed:", err)
+		}
+		return
+	}
+
+	if len(args) == 0 {
+		log.Fatalln("receiver id is required")
+	}
+
+	if *threshold <= 0 || *threshold > len(args) {
+		log.Fatalln("invalid threshold")
+	}
+
+	var receivers []*mixin.User
+	for _, id := range args {
+		receiver, err := fetchUserInfo(ctx, id)
+		if err != nil {
+			log.Fatalln("read receiver info failed:", err)
+		}
+
+		if cast.ToInt64(receiver.IdentityNumber) == 0 {
+			log.Fatalln("receiver is not a mixin messenger user")
+		}
+
+		if receiver.UserID == client.ClientID {
+			log.Fatalln("receiver is self")
+		}
+
+		receivers = append(receivers, receiver)
+	}
+
+	r.receivers = receiverIDs(receivers)
+	r.threshold = uint8(*threshold)
+
+	state, err := loadCheckpointState(r.statePath())
 	if err != nil {
-		log.Fatalln("read receiver info failed:", err)
+		log.Fatalln("load checkpoint state failed:", err)
 	}
 
-	if cast.ToInt64(receiver.IdentityNumber) == 0 {
-		log.Fatalln("receiver is not a mixin messenger user")
+	safeAssets, err := r.listSafeAssetGroups(ctx)
+	if err != nil {
+		log.Fatalln("list safe assets failed:", err)
 	}
 
-	if receiver.UserID == client.ClientID {
-		log.Fatalln("receiver is self")
+	if mode == "plan" {
+		b, err := r.planSafeAssets(ctx, state, safeAssets)
+		if err != nil {
+			log.Fatalln("plan safe assets failed:", err)
+		}
+
+		if b == nil {
+			return
+		}
+
+		if err := bundle.Save(r.bundlePath(), b); err != nil {
+			log.Fatalln("save bundle failed:", err)
+		}
+
+		printBundleManifest(b)
+		log.Println("wrote unsigned bundle to", r.bundlePath())
+		return
 	}
 
-	log.Printf("migrate assets to %s(%s)", receiver.FullName, receiver.UserID)
-	if !conformContinue() {
+	for _, receiver := range receivers {
+		log.Printf("migrate assets to %s(%s)", receiver.FullName, receiver.UserID)
+	}
+	if len(receivers) > 1 {
+		log.Printf("using a %d-of-%d mix address", r.threshold, len(receivers))
+	}
+
+	plan, err := r.buildMigrationPlan(ctx, state, safeAssets)
+	if err != nil {
+		log.Fatalln("build migration plan failed:", err)
+	}
+
+	printMigrationPlan(plan)
+
+	if *dryRun {
 		return
 	}
 
-	r := &runner{
-		client:   client,
-		key:      key,
-		output:   *keystorePath,
-		receiver: receiver.UserID,
+	if mode != "resume" && !cli.Confirm("Continue") {
+		return
 	}
 
 	if err := r.migrateLegacyAssets(ctx); err != nil {
@@ -83,16 +193,79 @@ func main() {
 		log.Fatalln("migrate safe failed:", err)
 	}
 
-	if err := r.migrateSafeAssets(ctx); err != nil {
+	if err := r.migrateSafeAssets(ctx, state, safeAssets); err != nil {
 		log.Fatalln("migrate safe assets failed:", err)
 	}
 }
 
 type runner struct {
-	client   *mixin.Client
-	key      *Keystore
-	output   string
-	receiver string
+	client        *mixin.Client
+	key           *Keystore
+	output        string
+	receivers     []string
+	threshold     uint8
+	includeAssets map[string]bool
+	excludeAssets map[string]bool
+	limiter       *rateLimiter
+}
+
+// receiverKey deterministically identifies the receiver set, for use in the
+// checkpoint RequestID derivation: changing the receivers or the threshold
+// must change the derived RequestID so a group is never replayed against a
+// different destination.
+func (r *runner) receiverKey() string {
+	ids := append([]string(nil), r.receivers...)
+	sort.Strings(ids)
+	return fmt.Sprintf("%s/%d", strings.Join(ids, ","), r.threshold)
+}
+
+// assetAllowed reports whether assetID passes the -assets/-skip-assets
+// filters.
+func (r *runner) assetAllowed(assetID string) bool {
+	if len(r.includeAssets) > 0 && !r.includeAssets[assetID] {
+		return false
+	}
+
+	return !r.excludeAssets[assetID]
+}
+
+func receiverIDs(users []*mixin.User) []string {
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.UserID
+	}
+	return ids
+}
+
+func parseAssetFilter(s string) map[string]bool {
+	if s == "" {
+		return nil
+	}
+
+	ids := map[string]bool{}
+	for _, id := range strings.Split(s, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids[id] = true
+		}
+	}
+
+	return ids
+}
+
+func (r *runner) statePath() string {
+	if *statePath != "" {
+		return *statePath
+	}
+
+	return r.output + ".state.json"
+}
+
+func (r *runner) bundlePath() string {
+	if *bundlePath != "" {
+		return *bundlePath
+	}
+
+	return r.output + ".bundle.json"
 }
 
 func (r *runner) saveKeystore() error {
@@ -120,7 +293,7 @@ func (r *runner) migrateLegacyAssets(ctx context.Context) error {
 
 	var idx int
 	for _, asset := range assets {
-		if asset.Balance.IsZero() {
+		if asset.Balance.IsZero() || !r.assetAllowed(asset.AssetID) {
 			continue
 		}
 
@@ -138,26 +311,68 @@ func (r *runner) migrateLegacyAssets(ctx context.Context) error {
 
 	for _, asset := range assets {
 		log.Println("migrating legacy asset", asset.Balance, asset.Symbol)
-		t := &mixin.TransferInput{
-			AssetID:    asset.AssetID,
-			OpponentID: r.receiver,
-			Amount:     asset.Balance,
-			TraceID:    mixin.RandomTraceID(),
-			Memo:       "migrate by mixin-migrate",
-		}
 
-		snapshot, err := r.client.Transfer(ctx, t, r.key.Pin)
-		if err != nil {
-			return fmt.Errorf("transfer %s %s failed: %w", asset.Balance, asset.Symbol, err)
-		}
+		for _, share := range splitAmount(asset.Balance, r.receivers) {
+			t := &mixin.TransferInput{
+				AssetID:    asset.AssetID,
+				OpponentID: share.receiver,
+				Amount:     share.amount,
+				TraceID:    mixin.RandomTraceID(),
+				Memo:       "migrate by mixin-migrate",
+			}
 
-		log.Println("migrated legacy asset", asset.Balance, asset.Symbol, "snapshot", snapshot.SnapshotID)
+			snapshot, err := r.client.Transfer(ctx, t, r.key.Pin)
+			if err != nil {
+				return fmt.Errorf("transfer %s %s failed: %w", share.amount, asset.Symbol, err)
+			}
+
+			log.Println("migrated legacy asset", share.amount, asset.Symbol, "to", share.receiver, "snapshot", snapshot.SnapshotID)
+		}
 	}
 
 	log.Printf("migrate legacy assets done\n")
 	return nil
 }
 
+type receiverShare struct {
+	receiver string
+	amount   decimal.Decimal
+}
+
+// splitAmount divides amount evenly across receivers, since a legacy
+// Transfer has a single opponent and cannot target a mix address the way a
+// safe transaction can. Any rounding remainder is folded into the last
+// receiver's share so the full amount is always accounted for. Receivers
+// whose share would round down to zero (amount too small to split at
+// 8-decimal precision) are dropped rather than emitting a zero-amount
+// Transfer.
+func splitAmount(amount decimal.Decimal, receivers []string) []receiverShare {
+	if len(receivers) == 1 {
+		return []receiverShare{{receiver: receivers[0], amount: amount}}
+	}
+
+	share := amount.DivRound(decimal.NewFromInt(int64(len(receivers))), 8)
+	remaining := amount
+
+	shares := make([]receiverShare, 0, len(receivers))
+	for i, receiver := range receivers {
+		amt := share
+		if i == len(receivers)-1 {
+			amt = remaining
+		} else {
+			remaining = remaining.Sub(share)
+		}
+
+		if amt.IsZero() {
+			continue
+		}
+
+		shares = append(shares, receiverShare{receiver: receiver, amount: amt})
+	}
+
+	return shares
+}
+
 func (r *runner) updateTipPin(ctx context.Context) error {
 	if _, err := mixinnet.KeyFromString(r.key.Pin); err == nil {
 		log.Println("updated to tip pin already")
@@ -206,7 +421,7 @@ func (r *runner) migrateToSafe(ctx context.Context) error {
 	return nil
 }
 
-func (r *runner) migrateSafeAssets(ctx context.Context) error {
+func (r *runner) listSafeUtxosByAsset(ctx context.Context) (map[string][]*mixin.SafeUtxo, error) {
 	assets := map[string][]*mixin.SafeUtxo{}
 
 	opt := mixin.SafeListUtxoOption{
@@ -217,9 +432,14 @@ func (r *runner) migrateSafeAssets(ctx context.Context) error {
 	}
 
 	for {
-		utxos, err := r.client.SafeListUtxos(ctx, opt)
+		var utxos []*mixin.SafeUtxo
+		err := r.withRetry(ctx, func() error {
+			var err error
+			utxos, err = r.client.SafeListUtxos(ctx, opt)
+			return err
+		})
 		if err != nil {
-			return fmt.Errorf("list safe utxos failed: %w", err)
+			return nil, fmt.Errorf("list safe utxos failed: %w", err)
 		}
 
 		if len(utxos) == 0 {
@@ -228,80 +448,342 @@ func (r *runner) migrateSafeAssets(ctx context.Context) error {
 
 		for _, utxo := range utxos {
 			opt.Offset = utxo.Sequence + 1
-			assets[utxo.AssetID] = append(assets[utxo.AssetID], utxo)
+			if r.assetAllowed(utxo.AssetID) {
+				assets[utxo.AssetID] = append(assets[utxo.AssetID], utxo)
+			}
 		}
 	}
 
-	if len(assets) == 0 {
-		log.Println("no safe assets")
-		return nil
+	return assets, nil
+}
+
+// safeReadAsset wraps SafeReadAsset with the shared rate limiter and retry
+// helper, since it is called once per asset from several read and write
+// paths (planning, status, dry-run).
+func (r *runner) safeReadAsset(ctx context.Context, assetID string) (*mixin.SafeAsset, error) {
+	var asset *mixin.SafeAsset
+	err := r.withRetry(ctx, func() error {
+		var err error
+		asset, err = r.client.SafeReadAsset(ctx, assetID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read safe asset %s failed: %w", assetID, err)
+	}
+
+	return asset, nil
+}
+
+// safeAssetGroup is one asset's outstanding safe UTXOs together with its
+// resolved symbol, fetched once per `run`/`resume`/`plan` invocation and
+// shared between the printed migration plan and the actual planning pass,
+// so a live migration pages every safe UTXO and resolves every asset symbol
+// exactly once instead of once to print the plan and again to build it.
+type safeAssetGroup struct {
+	assetID string
+	symbol  string
+	utxos   []*mixin.SafeUtxo
+}
+
+// listSafeAssetGroups pages every outstanding safe UTXO and resolves each
+// asset's symbol, in one pass reused by buildMigrationPlan and
+// planSafeAssets.
+func (r *runner) listSafeAssetGroups(ctx context.Context) ([]*safeAssetGroup, error) {
+	byAsset, err := r.listSafeUtxosByAsset(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*safeAssetGroup, 0, len(byAsset))
+	for assetID, utxos := range byAsset {
+		asset, err := r.safeReadAsset(ctx, assetID)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, &safeAssetGroup{assetID: assetID, symbol: asset.Symbol, utxos: utxos})
+	}
+
+	return groups, nil
+}
+
+// migrateSafeAssets runs the all-in-one flow: plan every outstanding group
+// into a bundle, sign it with the in-memory spend key, then submit it. It is
+// implemented on top of the same plan/sign/submit pipeline that backs the
+// `plan`, `mixin-migrate-wallet sign`, and `submit` commands, so there is
+// exactly one signing path. state and safeAssets are the ones already
+// loaded for the printed migration plan, so this does not re-page the safe
+// UTXOs or re-resolve asset symbols.
+func (r *runner) migrateSafeAssets(ctx context.Context, state *CheckpointState, safeAssets []*safeAssetGroup) error {
+	b, err := r.planSafeAssets(ctx, state, safeAssets)
+	if err != nil {
+		return err
 	}
 
-	log.Printf("start migrating %d safe assets\n", len(assets))
+	if b == nil {
+		return nil
+	}
 
 	spendKey, err := mixinnet.KeyFromString(r.key.SpendKey)
 	if err != nil {
 		return fmt.Errorf("invalid spend key: %w", err)
 	}
 
-	for assetId, utxos := range assets {
-		asset, err := r.client.SafeReadAsset(ctx, assetId)
+	if err := b.Sign(spendKey); err != nil {
+		return err
+	}
+
+	return r.submitBundle(ctx, b)
+}
+
+// planGroup is one asset's worth of UTXOs cut down to -group size, queued up
+// to be built into a safe transaction request. Since the groups it spends
+// never overlap by construction, planning them via runWorkers never risks
+// two workers racing to spend the same UTXO.
+type planGroup struct {
+	assetID string
+	symbol  string
+	spends  []*mixin.SafeUtxo
+}
+
+// planSafeAssets groups every safe UTXO in safeAssets by -group size, and
+// calls SafeCreateTransactionRequest for every group that is not already
+// confirmed in state, returning an unsigned bundle ready to be signed
+// either in-process (migrateSafeAssets) or offline (mixin-migrate-wallet).
+// Groups are built by up to -concurrency workers sharing the rate limiter,
+// and a group that fails only drops out of the bundle instead of aborting
+// the rest of the plan.
+func (r *runner) planSafeAssets(ctx context.Context, state *CheckpointState, safeAssets []*safeAssetGroup) (*bundle.Bundle, error) {
+	if len(safeAssets) == 0 {
+		log.Println("no safe assets")
+		return nil, nil
+	}
+
+	log.Printf("start planning %d safe assets\n", len(safeAssets))
+
+	var groups []planGroup
+	for _, sa := range safeAssets {
+		log.Println("planning safe asset", sumUtxos(sa.utxos), sa.symbol)
+
+		for idx := 0; idx < len(sa.utxos); idx += *spendGroupCount {
+			spends := sa.utxos[idx:min(len(sa.utxos), idx+*spendGroupCount)]
+			groups = append(groups, planGroup{assetID: sa.assetID, symbol: sa.symbol, spends: spends})
+		}
+	}
+
+	built := make([]*bundle.Group, len(groups))
+	errs := runWorkers(ctx, *concurrency, groups, func(ctx context.Context, i int, g planGroup) error {
+		group, err := r.buildSafeGroup(ctx, state, g)
 		if err != nil {
-			return fmt.Errorf("read safe asset %s failed: %w", assetId, err)
+			return err
 		}
 
-		log.Println("migrating safe asset", sumUtxos(utxos), asset.Symbol)
+		built[i] = group
+		return nil
+	})
 
-		for idx := 0; idx < len(utxos); idx += *spendGroupCount {
-			spends := utxos[idx:min(len(utxos), idx+*spendGroupCount)]
-			b := mixin.NewSafeTransactionBuilder(spends)
-			b.Memo = "migrate by mixin-migrate"
+	b := &bundle.Bundle{Receivers: r.receivers, Threshold: r.threshold}
+	manifests := map[string]*bundle.Manifest{}
 
-			output := mixin.TransactionOutput{
-				Address: mixin.RequireNewMixAddress([]string{r.receiver}, 1),
-				Amount:  sumUtxos(spends),
-			}
+	var failed int
+	for i, err := range errs {
+		if err != nil {
+			failed++
+			log.Printf("plan group failed asset=%s utxos=%d: %v\n", groups[i].assetID, len(groups[i].spends), err)
+			continue
+		}
 
-			tx, err := r.client.MakeTransaction(ctx, b, []*mixin.TransactionOutput{&output})
-			if err != nil {
-				return fmt.Errorf("make safe transaction failed: %w", err)
-			}
+		group := built[i]
+		if group == nil {
+			continue
+		}
 
-			raw, err := tx.Dump()
-			if err != nil {
-				return fmt.Errorf("dump transaction failed: %w", err)
-			}
+		manifest, ok := manifests[group.AssetID]
+		if !ok {
+			manifest = &bundle.Manifest{AssetID: group.AssetID, Symbol: group.Symbol}
+			manifests[group.AssetID] = manifest
+			b.Manifests = append(b.Manifests, manifest)
+		}
+
+		manifest.Groups++
+		manifest.Utxos += len(group.Utxos)
+		manifest.Amount = manifest.Amount.Add(group.Amount)
+
+		b.Groups = append(b.Groups, group)
+	}
+
+	if failed > 0 {
+		log.Printf("%d of %d groups failed to plan, see log above for details\n", failed, len(groups))
+	}
+
+	if len(b.Groups) == 0 {
+		log.Println("nothing to plan, every group is already confirmed")
+		return nil, nil
+	}
+
+	return b, nil
+}
+
+// buildSafeGroup builds and submits the transaction request for one plan
+// group, returning a nil group (and nil error) if it is already confirmed.
+// A group that already reached "built" still goes through build again here:
+// since gs.RequestID is re-derived deterministically, SafeCreateTransactionRequest
+// is a safe retry of the same request rather than a duplicate spend.
+func (r *runner) buildSafeGroup(ctx context.Context, state *CheckpointState, g planGroup) (*bundle.Group, error) {
+	key := groupKey(g.spends)
+	phase, requestID := state.ensureRequestID(key, r.receiverKey())
+
+	if phase == GroupPhaseConfirmed {
+		log.Println("skip already confirmed group", key)
+		return nil, nil
+	}
+
+	txb := mixin.NewSafeTransactionBuilder(g.spends)
+	txb.Memo = "migrate by mixin-migrate"
+	txb.Hint = requestID
+
+	amount := sumUtxos(g.spends)
+	output := mixin.TransactionOutput{
+		Address: mixin.RequireNewMixAddress(r.receivers, r.threshold),
+		Amount:  amount,
+	}
+
+	tx, err := r.client.MakeTransaction(ctx, txb, []*mixin.TransactionOutput{&output})
+	if err != nil {
+		return nil, fmt.Errorf("make safe transaction failed: %w", err)
+	}
+
+	raw, err := tx.Dump()
+	if err != nil {
+		return nil, fmt.Errorf("dump transaction failed: %w", err)
+	}
+
+	var req *mixin.SafeTransactionRequest
+	err = r.withRetry(ctx, func() error {
+		var err error
+		req, err = r.client.SafeCreateTransactionRequest(ctx, &mixin.SafeTransactionRequestInput{
+			RequestID:      requestID,
+			RawTransaction: raw,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create transaction request failed: %w", err)
+	}
+
+	utxos := utxoOutputIDs(g.spends)
+	state.markBuilt(key, g.assetID, utxos)
+	if err := state.save(); err != nil {
+		return nil, err
+	}
+
+	return &bundle.Group{
+		AssetID:        g.assetID,
+		Symbol:         g.symbol,
+		RequestID:      req.RequestID,
+		Utxos:          utxos,
+		Amount:         amount,
+		RawTransaction: raw,
+		Views:          req.Views,
+	}, nil
+}
+
+// submitBundle submits every signed group in the bundle using up to
+// -concurrency workers sharing the rate limiter, marking each confirmed in
+// the checkpoint state as it lands. A group that fails to submit is
+// reported in the summary at the end rather than aborting the groups still
+// in flight; it stays unconfirmed in the checkpoint so a resume retries it.
+func (r *runner) submitBundle(ctx context.Context, b *bundle.Bundle) error {
+	state, err := loadCheckpointState(r.statePath())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("start submitting %d groups\n", len(b.Groups))
+
+	errs := runWorkers(ctx, *concurrency, b.Groups, func(ctx context.Context, i int, g *bundle.Group) error {
+		if !g.Signed {
+			return fmt.Errorf("group %s is not signed", g.RequestID)
+		}
 
-			req, err := r.client.SafeCreateTransactionRequest(ctx, &mixin.SafeTransactionRequestInput{
-				RequestID:      b.Hint,
-				RawTransaction: raw,
+		if err := r.withRetry(ctx, func() error {
+			_, err := r.client.SafeSubmitTransactionRequest(ctx, &mixin.SafeTransactionRequestInput{
+				RequestID:      g.RequestID,
+				RawTransaction: g.RawTransaction,
 			})
+			return err
+		}); err != nil {
+			return fmt.Errorf("submit transaction request failed: %w", err)
+		}
 
-			if err != nil {
-				return fmt.Errorf("create transaction request failed: %w", err)
-			}
+		state.markConfirmed(groupKeyFromIDs(g.Utxos))
+		return state.save()
+	})
 
-			if err := mixin.SafeSignTransaction(tx, spendKey, req.Views, 0); err != nil {
-				return fmt.Errorf("sign transaction failed: %w", err)
-			}
+	var failed int
+	for i, err := range errs {
+		if err != nil {
+			failed++
+			log.Printf("submit group failed request_id=%s: %v\n", b.Groups[i].RequestID, err)
+		}
+	}
 
-			signedRaw, err := tx.Dump()
-			if err != nil {
-				return fmt.Errorf("dump transaction failed: %w", err)
-			}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d groups failed to submit, see log above for details", failed, len(b.Groups))
+	}
 
-			if _, err := r.client.SafeSubmitTransactionRequest(ctx, &mixin.SafeTransactionRequestInput{
-				RequestID:      req.RequestID,
-				RawTransaction: signedRaw,
-			}); err != nil {
-				return fmt.Errorf("submit transaction request failed: %w", err)
+	log.Println("migrate safe assets done")
+	return nil
+}
+
+// printBundleManifest prints a human-readable summary of a bundle so an
+// operator can sanity check it before signing or submitting.
+func printBundleManifest(b *bundle.Bundle) {
+	log.Printf("bundle for receivers %s (threshold %d)\n", strings.Join(b.Receivers, ","), b.Threshold)
+	for _, m := range b.Manifests {
+		log.Printf("  %-8s %d groups, %d utxos, amount %s\n", m.Symbol, m.Groups, m.Utxos, m.Amount)
+	}
+	log.Printf("  total amount across all assets: %s\n", b.TotalAmount())
+}
+
+// printSafeAssetsStatus lists outstanding safe-asset migration work without
+// submitting anything, reflecting whatever progress is on record in the
+// checkpoint state.
+func (r *runner) printSafeAssetsStatus(ctx context.Context) error {
+	assets, err := r.listSafeUtxosByAsset(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(assets) == 0 {
+		log.Println("no safe assets")
+		return nil
+	}
+
+	state, err := loadCheckpointState(r.statePath())
+	if err != nil {
+		return err
+	}
+
+	for assetId, utxos := range assets {
+		asset, err := r.safeReadAsset(ctx, assetId)
+		if err != nil {
+			return err
+		}
+
+		var remaining, confirmed int
+		for idx := 0; idx < len(utxos); idx += *spendGroupCount {
+			spends := utxos[idx:min(len(utxos), idx+*spendGroupCount)]
+			if state.group(groupKey(spends)).Phase == GroupPhaseConfirmed {
+				confirmed++
+			} else {
+				remaining++
 			}
 		}
 
-		log.Println("migrated safe asset", sumUtxos(utxos), asset.Symbol)
+		log.Printf("%s %s: %d utxos, %d groups done, %d groups remaining\n", asset.Symbol, sumUtxos(utxos), len(utxos), confirmed, remaining)
 	}
 
-	log.Println("migrate safe assets done")
 	return nil
 }
 
@@ -332,16 +814,3 @@ func fetchUserInfo(ctx context.Context, id string) (*mixin.User, error) {
 
 	return &body.Data, nil
 }
-
-func conformContinue() bool {
-	prompt := promptui.Prompt{
-		Label:     "Continue",
-		IsConfirm: true,
-	}
-	result, err := prompt.Run()
-	if err != nil {
-		return false
-	}
-
-	return strings.EqualFold(result, "y")
-}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestSplitAmountSingleReceiverGetsTheWholeAmount(t *testing.T) {
+	shares := splitAmount(decimal.NewFromFloat(1.23456789), []string{"r1"})
+	if len(shares) != 1 || !shares[0].amount.Equal(decimal.NewFromFloat(1.23456789)) {
+		t.Fatalf("unexpected shares: %+v", shares)
+	}
+}
+
+func TestSplitAmountEvenlyDivides(t *testing.T) {
+	shares := splitAmount(decimal.NewFromInt(9), []string{"r1", "r2", "r3"})
+
+	total := decimal.Zero
+	for _, s := range shares {
+		total = total.Add(s.amount)
+	}
+
+	if !total.Equal(decimal.NewFromInt(9)) {
+		t.Fatalf("shares do not sum to the original amount: %s", total)
+	}
+
+	for _, s := range shares {
+		if !s.amount.Equal(decimal.NewFromInt(3)) {
+			t.Fatalf("expected an even 3-way split, got %s for %s", s.amount, s.receiver)
+		}
+	}
+}
+
+func TestSplitAmountRemainderGoesToLastReceiver(t *testing.T) {
+	// 1 / 3 does not divide evenly at 8 decimal places, so the rounding
+	// remainder must be folded into the last share rather than dropped.
+	amount := decimal.NewFromInt(1)
+	receivers := []string{"r1", "r2", "r3"}
+	shares := splitAmount(amount, receivers)
+
+	total := decimal.Zero
+	for _, s := range shares {
+		total = total.Add(s.amount)
+	}
+
+	if !total.Equal(amount) {
+		t.Fatalf("shares must sum to the original amount exactly, got %s want %s", total, amount)
+	}
+
+	for i, s := range shares[:len(shares)-1] {
+		if !s.amount.Equal(decimal.NewFromInt(1).DivRound(decimal.NewFromInt(3), 8)) {
+			t.Fatalf("share %d should be the evenly rounded amount, got %s", i, s.amount)
+		}
+	}
+
+	last := shares[len(shares)-1]
+	if last.receiver != receivers[len(receivers)-1] {
+		t.Fatalf("last share should belong to the last receiver, got %s", last.receiver)
+	}
+}
+
+func TestSplitAmountDropsZeroShares(t *testing.T) {
+	// 1e-8 split 3 ways rounds every non-last share down to zero; those
+	// must be dropped rather than handed to Transfer as a zero-amount
+	// input, leaving the whole amount on the last receiver.
+	amount := decimal.NewFromFloat(0.00000001)
+	receivers := []string{"r1", "r2", "r3"}
+	shares := splitAmount(amount, receivers)
+
+	if len(shares) != 1 {
+		t.Fatalf("expected only the non-zero share to remain, got %+v", shares)
+	}
+
+	if shares[0].receiver != receivers[len(receivers)-1] || !shares[0].amount.Equal(amount) {
+		t.Fatalf("expected the full amount on the last receiver, got %+v", shares[0])
+	}
+}
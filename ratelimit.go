@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/fox-one/mixin-sdk-go/v2"
+)
+
+const (
+	maxRetryAttempts = 5
+	retryBaseDelay   = 200 * time.Millisecond
+	retryMaxDelay    = 10 * time.Second
+)
+
+// rateLimiter is a minimal shared token bucket, used to cap the aggregate
+// rate of outgoing Mixin API calls across every worker.
+type rateLimiter struct {
+	mu       sync.Mutex
+	tokens   float64
+	capacity float64
+	rate     float64
+	lastFill time.Time
+}
+
+func newRateLimiter(rps float64) *rateLimiter {
+	if rps <= 0 {
+		rps = 1
+	}
+
+	return &rateLimiter{tokens: rps, capacity: rps, rate: rps, lastFill: time.Now()}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = min(l.capacity, l.tokens+now.Sub(l.lastFill).Seconds()*l.rate)
+		l.lastFill = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// withRetry rate-limits and retries fn, distinguishing non-retryable 4xx
+// Mixin API errors from retryable 5xx/timeout/429 errors, backing off
+// exponentially with jitter between attempts.
+func (r *runner) withRetry(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < maxRetryAttempts; attempt++ {
+		if r.limiter != nil {
+			if err := r.limiter.wait(ctx); err != nil {
+				return err
+			}
+		}
+
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		if !retryable(err) {
+			return err
+		}
+
+		if attempt == maxRetryAttempts-1 {
+			break
+		}
+
+		delay := retryBaseDelay * time.Duration(1<<attempt)
+		if delay > retryMaxDelay {
+			delay = retryMaxDelay
+		}
+		delay += time.Duration(rand.Int63n(int64(delay/2 + 1)))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %w", maxRetryAttempts, err)
+}
+
+// retryable reports whether err is worth retrying: Mixin 4xx errors are
+// treated as permanent (bad request, insufficient balance, wrong pin, ...),
+// while 5xx, 429, and transport-level errors (timeouts, connection resets)
+// are assumed transient.
+func retryable(err error) bool {
+	var e *mixin.Error
+	if errors.As(err, &e) {
+		if e.Status == 429 {
+			return true
+		}
+
+		return e.Status >= 500 || e.Status == 0
+	}
+
+	return true
+}
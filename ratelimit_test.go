@@ -0,0 +1,30 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fox-one/mixin-sdk-go/v2"
+)
+
+func TestRetryableClassifiesMixinErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"4xx is permanent", &mixin.Error{Status: 400}, false},
+		{"429 is retryable", &mixin.Error{Status: 429}, true},
+		{"5xx is retryable", &mixin.Error{Status: 500}, true},
+		{"zero status (transport-level) is retryable", &mixin.Error{Status: 0}, true},
+		{"non-mixin error is retryable", errors.New("connection reset"), true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := retryable(c.err); got != c.want {
+				t.Fatalf("retryable(%v) = %v, want %v", c.err, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// runWorkers calls fn for every item in items using up to concurrency
+// goroutines at a time, and returns one error per item (nil for success) in
+// the same order as items. A failure in one item never stops the others
+// from running, so callers can collect a per-item summary instead of
+// failing the whole batch fast.
+func runWorkers[T any](ctx context.Context, concurrency int, items []T, fn func(ctx context.Context, index int, item T) error) []error {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	errs := make([]error, len(items))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			errs[i] = fn(ctx, i, item)
+		}(i, item)
+	}
+
+	wg.Wait()
+	return errs
+}
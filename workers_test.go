@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunWorkersBoundsConcurrencyAndPreservesOrder(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+
+	var inFlight, maxInFlight int64
+	errs := runWorkers(context.Background(), 3, items, func(ctx context.Context, i int, item int) error {
+		n := atomic.AddInt64(&inFlight, 1)
+		for {
+			max := atomic.LoadInt64(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt64(&maxInFlight, max, n) {
+				break
+			}
+		}
+		atomic.AddInt64(&inFlight, -1)
+
+		if item == 4 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	if atomic.LoadInt64(&maxInFlight) > 3 {
+		t.Fatalf("expected at most 3 concurrent workers, saw %d", maxInFlight)
+	}
+
+	for i, err := range errs {
+		if i == 4 {
+			if err == nil {
+				t.Fatalf("expected item 4 to fail")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("item %d should not have failed a sibling's error: %v", i, err)
+		}
+	}
+}